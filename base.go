@@ -0,0 +1,100 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/os/v2"
+	"github.com/juju/utils/v3/arch"
+)
+
+// Base represents an OS/Channel pair that a charm or bundle can be deployed
+// to, such as ubuntu/20.04/stable. It is the replacement for the legacy
+// "series" string, and unlike series it generalizes across operating
+// systems rather than enumerating Ubuntu release codenames.
+type Base struct {
+	Name          string   `json:"name" yaml:"name"`
+	Channel       Channel  `json:"channel" yaml:"channel"`
+	Architectures []string `json:"architectures,omitempty" yaml:"architectures,omitempty"`
+}
+
+// knownOS is the set of operating system names that ParseBase recognises.
+var knownOS = map[string]bool{
+	strings.ToLower(os.Ubuntu.String()):       true,
+	strings.ToLower(os.Windows.String()):      true,
+	strings.ToLower(os.CentOS.String()):       true,
+	strings.ToLower(os.GenericLinux.String()): true,
+	strings.ToLower(os.OSX.String()):          true,
+	strings.ToLower(os.Kubernetes.String()):   true,
+}
+
+// ParseBase parses a base from a string of the form "os/track/risk",
+// optionally suffixed with " on arch1,arch2" to describe its supported
+// architectures. Architectures may instead be supplied explicitly via
+// archs, in which case the string must not itself contain an " on " suffix.
+func ParseBase(s string, archs ...string) (Base, error) {
+	original := s
+
+	newErr := func(reason string) (Base, error) {
+		if len(archs) > 0 {
+			return Base{}, fmt.Errorf("invalid base string %q with architectures %q: %s", original, strings.Join(archs, ","), reason)
+		}
+		return Base{}, fmt.Errorf("invalid base string %q: %s", original, reason)
+	}
+
+	if idx := strings.Index(s, " on "); idx != -1 {
+		if len(archs) != 0 {
+			return newErr("architectures specified both in the base string and as an argument")
+		}
+		for _, a := range strings.Split(s[idx+len(" on "):], ",") {
+			archs = append(archs, strings.TrimSpace(a))
+		}
+		s = s[:idx]
+	}
+
+	parts := strings.SplitN(s, "/", 2)
+	name := strings.ToLower(parts[0])
+	if !knownOS[name] {
+		return newErr(fmt.Sprintf("os %q not valid", name))
+	}
+	if len(parts) != 2 {
+		return newErr("channel not valid")
+	}
+	channel, err := ParseChannelNormalize(parts[1])
+	if err != nil {
+		return newErr("channel not valid")
+	}
+
+	var architectures []string
+	for _, a := range archs {
+		normalized := arch.NormaliseArch(a)
+		if !arch.IsSupportedArch(normalized) {
+			return newErr(fmt.Sprintf("architecture %q not valid", a))
+		}
+		architectures = append(architectures, normalized)
+	}
+
+	return Base{
+		Name:          name,
+		Channel:       channel,
+		Architectures: architectures,
+	}, nil
+}
+
+// String returns the base in its "os/track/risk" form, followed by
+// " on arch1, arch2" if any architectures are set. An empty channel is
+// omitted, so a base with only a name renders as e.g. "ubuntu"; ParseBase
+// does not accept that form, since it requires a channel.
+func (b Base) String() string {
+	str := strings.ToLower(b.Name)
+	if ch := b.Channel.String(); ch != "" {
+		str += "/" + ch
+	}
+	if len(b.Architectures) > 0 {
+		str += " on " + strings.Join(b.Architectures, ", ")
+	}
+	return str
+}
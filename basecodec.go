@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// rawBase is Base's field layout, used to mask Base's own
+// Marshal/Unmarshal methods while (de)serializing its fields.
+type rawBase struct {
+	Name          string   `yaml:"name" bson:"name"`
+	Channel       Channel  `yaml:"channel" bson:"channel"`
+	Architectures []string `yaml:"architectures,omitempty" bson:"architectures,omitempty"`
+}
+
+// MarshalYAML implements yaml.Marshaler, producing the same
+// name/channel/architectures mapping that the default struct encoding
+// would, so Base round-trips unchanged through bundle, metadata and
+// manifest YAML.
+func (b Base) MarshalYAML() (interface{}, error) {
+	return rawBase(b), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. It accepts either the full
+// name/channel/architectures mapping, or the compact scalar form
+// "ubuntu/20.04/stable" used throughout hand-written bundle YAML, which
+// is parsed via ParseBase.
+func (b *Base) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var compact string
+	if err := unmarshal(&compact); err == nil {
+		base, err := ParseBase(compact)
+		if err != nil {
+			return err
+		}
+		*b = base
+		return nil
+	}
+
+	var raw rawBase
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*b = Base(raw)
+	return nil
+}
+
+// GetBSON implements bson.Getter, so Base travels through mongo-backed
+// state the same way it travels through YAML and JSON.
+func (b Base) GetBSON() (interface{}, error) {
+	return rawBase(b), nil
+}
+
+// SetBSON implements bson.Setter.
+func (b *Base) SetBSON(raw bson.Raw) error {
+	var v rawBase
+	if err := raw.Unmarshal(&v); err != nil {
+		return err
+	}
+	*b = Base(v)
+	return nil
+}
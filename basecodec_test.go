@@ -0,0 +1,77 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/charm/v9"
+)
+
+type baseCodecSuite struct{}
+
+var _ = gc.Suite(&baseCodecSuite{})
+
+func (s *baseCodecSuite) TestYAMLRoundTrip(c *gc.C) {
+	tests := []struct {
+		about string
+		base  charm.Base
+	}{
+		{
+			about: "empty channel",
+			base:  charm.Base{Name: "ubuntu"},
+		}, {
+			about: "missing name",
+			base:  charm.Base{Channel: mustParseChannel("20.04/stable")},
+		}, {
+			about: "architecture list",
+			base: charm.Base{
+				Name:          "ubuntu",
+				Channel:       mustParseChannel("20.04/stable"),
+				Architectures: []string{"amd64", "arm64"},
+			},
+		},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d: %s", i, t.about)
+		out, err := yaml.Marshal(t.base)
+		c.Assert(err, jc.ErrorIsNil, comment)
+
+		var got charm.Base
+		err = yaml.Unmarshal(out, &got)
+		c.Assert(err, jc.ErrorIsNil, comment)
+		c.Assert(got, jc.DeepEquals, t.base, comment)
+	}
+}
+
+func (s *baseCodecSuite) TestYAMLCompactScalarForm(c *gc.C) {
+	var got charm.Base
+	err := yaml.Unmarshal([]byte(`ubuntu/20.04/stable`), &got)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")})
+}
+
+func (s *baseCodecSuite) TestYAMLCompactScalarUnknownOS(c *gc.C) {
+	var got charm.Base
+	err := yaml.Unmarshal([]byte(`mythicalos/1.0/stable`), &got)
+	c.Assert(err, gc.ErrorMatches, `invalid base string "mythicalos/1.0/stable": os "mythicalos" not valid`)
+}
+
+func (s *baseCodecSuite) TestBSONRoundTrip(c *gc.C) {
+	base := charm.Base{
+		Name:          "ubuntu",
+		Channel:       mustParseChannel("20.04/stable"),
+		Architectures: []string{"amd64"},
+	}
+	out, err := bson.Marshal(base)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var got charm.Base
+	err = bson.Unmarshal(out, &got)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, base)
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BaseMatcher reports whether a given Base satisfies some selection
+// criteria, such as a wildcarded base spec.
+type BaseMatcher interface {
+	Match(Base) bool
+}
+
+type baseMatcher struct {
+	name  string
+	track string
+	risk  string
+	archs []string
+}
+
+// NewBaseMatcher parses spec, a string of the form
+// "os/track/risk on arch1,arch2", where any of os, track or risk may be
+// "*" to mean "match anything", and the " on ..." architecture suffix is
+// optional and itself may list several architectures. This mirrors the
+// selector/specificity semantics container runtimes use for image and
+// platform selection, which equality-only Base comparison can't express.
+func NewBaseMatcher(spec string) (BaseMatcher, error) {
+	original := spec
+	spec = strings.TrimSpace(spec)
+
+	var archs []string
+	if idx := strings.Index(spec, " on "); idx != -1 {
+		for _, a := range strings.Split(spec[idx+len(" on "):], ",") {
+			a = strings.TrimSpace(a)
+			if a != "*" {
+				archs = append(archs, a)
+			}
+		}
+		spec = strings.TrimSpace(spec[:idx])
+	}
+
+	parts := strings.Split(spec, "/")
+	if len(parts) == 0 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid base matcher %q", original)
+	}
+
+	m := &baseMatcher{archs: archs}
+	if parts[0] != "*" {
+		m.name = strings.ToLower(parts[0])
+	}
+	if len(parts) > 1 && parts[1] != "*" {
+		m.track = parts[1]
+	}
+	if len(parts) > 2 && parts[2] != "*" {
+		m.risk = parts[2]
+	}
+	return m, nil
+}
+
+// Match reports whether b satisfies every non-wildcard field of m.
+func (m *baseMatcher) Match(b Base) bool {
+	if m.name != "" && m.name != b.Name {
+		return false
+	}
+	if m.track != "" && m.track != b.Channel.Track {
+		return false
+	}
+	if m.risk != "" && m.risk != b.Channel.Risk {
+		return false
+	}
+	if len(m.archs) > 0 && len(archIntersection(m.archs, b.Architectures)) == 0 {
+		return false
+	}
+	return true
+}
+
+// archIntersection returns the architectures common to both a and b. An
+// empty slice on either side is treated as "supports any architecture",
+// so it is reported as intersecting with everything.
+func archIntersection(a, b []string) []string {
+	if len(a) == 0 || len(b) == 0 {
+		if len(a) > len(b) {
+			return a
+		}
+		return b
+	}
+	have := make(map[string]bool, len(b))
+	for _, x := range b {
+		have[x] = true
+	}
+	var out []string
+	for _, x := range a {
+		if have[x] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Less reports whether base a is a strictly worse choice than base b when
+// no other information distinguishes them: within the same OS name, a
+// newer track and a more stable risk sort later, and architecture lists
+// are then compared element-wise. It is defined in terms of Base.Compare.
+func Less(a, b Base) bool {
+	return a.Compare(b) < 0
+}
+
+// baseScore is the tuple Best ranks candidates by, most significant
+// field first: OS name match, track specificity, risk rank, and
+// architecture intersection size.
+type baseScore [4]int
+
+func (s baseScore) less(other baseScore) bool {
+	for i := range s {
+		if s[i] != other[i] {
+			return s[i] < other[i]
+		}
+	}
+	return false
+}
+
+func (s baseScore) equal(other baseScore) bool {
+	return s == other
+}
+
+// trackSpecificity scores how specifically candidate matches requested:
+// an exact track match beats a same-major-version match, which beats a
+// requested track of "" (any track at all).
+func trackSpecificity(requested, candidate string) int {
+	switch {
+	case requested == "":
+		return 0
+	case requested == candidate:
+		return 2
+	case trackMajor(requested) != "" && trackMajor(requested) == trackMajor(candidate):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// trackMajor returns the portion of a dotted track before the first dot,
+// e.g. "22" from "22.04", or "" if track has no dot.
+func trackMajor(track string) string {
+	if i := strings.Index(track, "."); i != -1 {
+		return track[:i]
+	}
+	return ""
+}
+
+// Best ranks candidates against requested by (1) OS name match, (2)
+// track specificity, (3) risk rank and (4) architecture intersection
+// size, and returns the highest-ranked strict match: a candidate whose
+// OS matches requested's (when requested names one) and whose track, if
+// requested names one, is at least a same-major-version match. Ties are
+// broken with Less.
+func Best(requested Base, candidates []Base) (Base, bool) {
+	var best Base
+	var bestScore baseScore
+	found := false
+
+	for _, cand := range candidates {
+		if requested.Name != "" && requested.Name != cand.Name {
+			continue
+		}
+		spec := trackSpecificity(requested.Channel.Track, cand.Channel.Track)
+		if spec < 0 {
+			continue
+		}
+		osMatch := 0
+		if requested.Name != "" {
+			osMatch = 1
+		}
+		score := baseScore{
+			osMatch,
+			spec,
+			riskRank[cand.Channel.Risk],
+			len(archIntersection(requested.Architectures, cand.Architectures)),
+		}
+		// Ties are broken by Less, so that among otherwise-equal
+		// candidates the one sorting last by Base.Compare (e.g. the
+		// newer track) wins rather than whichever happened to appear
+		// first in the slice.
+		if !found || bestScore.less(score) || (bestScore.equal(score) && Less(best, cand)) {
+			best, bestScore, found = cand, score, true
+		}
+	}
+	return best, found
+}
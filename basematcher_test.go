@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/charm/v9"
+)
+
+type baseMatcherSuite struct{}
+
+var _ = gc.Suite(&baseMatcherSuite{})
+
+func (s *baseMatcherSuite) TestBaseMatcherMatch(c *gc.C) {
+	tests := []struct {
+		spec    string
+		base    charm.Base
+		matches bool
+		err     string
+	}{
+		{
+			spec:    "ubuntu/*/stable",
+			base:    charm.Base{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")},
+			matches: true,
+		}, {
+			spec:    "ubuntu/*/stable",
+			base:    charm.Base{Name: "ubuntu", Channel: mustParseChannel("22.04/edge")},
+			matches: false,
+		}, {
+			spec:    "*/22.04/*",
+			base:    charm.Base{Name: "ubuntu", Channel: mustParseChannel("22.04/edge")},
+			matches: true,
+		}, {
+			spec:    "*/22.04/*",
+			base:    charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/edge")},
+			matches: false,
+		}, {
+			spec: "ubuntu/22.04/stable on amd64,arm64",
+			base: charm.Base{
+				Name:          "ubuntu",
+				Channel:       mustParseChannel("22.04/stable"),
+				Architectures: []string{"arm64"},
+			},
+			matches: true,
+		}, {
+			spec: "ubuntu/22.04/stable on amd64,arm64",
+			base: charm.Base{
+				Name:          "ubuntu",
+				Channel:       mustParseChannel("22.04/stable"),
+				Architectures: []string{"s390x"},
+			},
+			matches: false,
+		}, {
+			spec: "ubuntu/22.04/stable/extra/nonsense",
+			err:  `invalid base matcher "ubuntu/22.04/stable/extra/nonsense"`,
+		},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d", i)
+		m, err := charm.NewBaseMatcher(t.spec)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err, comment)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil, comment)
+		c.Check(m.Match(t.base), gc.Equals, t.matches, comment)
+	}
+}
+
+func (s *baseMatcherSuite) TestBest(c *gc.C) {
+	candidates := []charm.Base{
+		{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("22.04/edge")},
+		{Name: "centos", Channel: mustParseChannel("7/stable")},
+	}
+
+	best, ok := charm.Best(charm.Base{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")}, candidates)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(best, jc.DeepEquals, candidates[1])
+
+	best, ok = charm.Best(charm.Base{Name: "ubuntu"}, candidates)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(best, jc.DeepEquals, candidates[1])
+
+	_, ok = charm.Best(charm.Base{Name: "windows"}, candidates)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *baseMatcherSuite) TestLess(c *gc.C) {
+	older := charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")}
+	newer := charm.Base{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")}
+	c.Assert(charm.Less(older, newer), jc.IsTrue)
+	c.Assert(charm.Less(newer, older), jc.IsFalse)
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"fmt"
+)
+
+// BundleData holds the contents of a bundle.
+type BundleData struct {
+	// Applications holds one entry for each application
+	// that the bundle will create, indexed by the application name.
+	Applications map[string]*ApplicationSpec `yaml:"applications,omitempty"`
+
+	// Machines holds one entry for each machine referred to
+	// by unit placements, indexed by the id of the machine in
+	// the bundle, which need not correspond to the id of the
+	// machine once deployed.
+	Machines map[string]*MachineSpec `yaml:"machines,omitempty"`
+
+	// Series holds the default series to use when the bundle
+	// chooses not to specify a series for an application or machine.
+	Series string `yaml:"series,omitempty"`
+
+	// DefaultBase holds the default base to use when the bundle
+	// chooses not to specify a base for an application or machine.
+	DefaultBase Base `yaml:"default-base,omitempty"`
+}
+
+// MachineSpec holds the specification of a machine that can be
+// referred to by applications in a bundle.
+type MachineSpec struct {
+	Constraints string            `yaml:"constraints,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Series      string            `yaml:"series,omitempty"`
+	Base        Base              `yaml:"base,omitempty"`
+}
+
+// ApplicationSpec represents a single application that will
+// be deployed as part of a bundle.
+type ApplicationSpec struct {
+	Charm    string `yaml:"charm,omitempty"`
+	Series   string `yaml:"series,omitempty"`
+	Base     Base   `yaml:"base,omitempty"`
+	NumUnits int    `yaml:"num_units,omitempty"`
+}
+
+// VerificationError holds a set of verification failures.
+type VerificationError struct {
+	Errors []error
+}
+
+func (err *VerificationError) Error() string {
+	switch len(err.Errors) {
+	case 0:
+		return "no verification errors!"
+	case 1:
+		return err.Errors[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more)", err.Errors[0], len(err.Errors)-1)
+}
+
+// Verify is used to check for errors in the bundle data.
+//
+// Machine constraints are verified with verifyConstraints, as their
+// validity depends on environment details that BundleData itself does
+// not have access to. verifyStorage and verifyDevices are accepted but
+// not called: BundleData holds no storage or device specifications.
+func (data *BundleData) Verify(
+	verifyConstraints func(c string) error,
+	verifyStorage func(s string) error,
+	verifyDevices func(d string) error,
+) error {
+	verifier := &bundleDataVerifier{
+		verifyConstraints: verifyConstraints,
+		verifyStorage:     verifyStorage,
+		verifyDevices:     verifyDevices,
+		bd:                data,
+	}
+	return verifier.verify()
+}
+
+type bundleDataVerifier struct {
+	verifyConstraints func(c string) error
+	verifyStorage     func(s string) error
+	verifyDevices     func(d string) error
+	bd                *BundleData
+
+	errors []error
+}
+
+// addErrorf adds a formatted error to the verifier's list of errors.
+func (verifier *bundleDataVerifier) addErrorf(f string, a ...interface{}) {
+	verifier.errors = append(verifier.errors, fmt.Errorf(f, a...))
+}
+
+func (verifier *bundleDataVerifier) verify() error {
+	verifier.verifyMachineConstraints()
+	verifier.verifyMixedSeriesBasesMatch()
+
+	if len(verifier.errors) > 0 {
+		return &VerificationError{Errors: verifier.errors}
+	}
+	return nil
+}
+
+func (verifier *bundleDataVerifier) verifyMachineConstraints() {
+	for id, m := range verifier.bd.Machines {
+		if m == nil || m.Constraints == "" {
+			continue
+		}
+		if verifier.verifyConstraints == nil {
+			continue
+		}
+		if err := verifier.verifyConstraints(m.Constraints); err != nil {
+			verifier.addErrorf("invalid constraints %q in machine %q: %v", m.Constraints, id, err)
+		}
+	}
+}
+
+// verifyMixedSeriesBasesMatch checks that, wherever a series and a base
+// are both supplied for the same entity, they agree, by converting the
+// base to a series with SeriesFromBase. A base with no corresponding
+// series is reported as a mismatch.
+func (verifier *bundleDataVerifier) verifyMixedSeriesBasesMatch() {
+	check := func(series string, base Base) {
+		if series == "" || base.Name == "" {
+			return
+		}
+		baseSeries, err := SeriesFromBase(base)
+		if err != nil || baseSeries != series {
+			verifier.addErrorf("bundle series %q and base %q must match if supplied", series, base.String())
+		}
+	}
+
+	check(verifier.bd.Series, verifier.bd.DefaultBase)
+	for _, m := range verifier.bd.Machines {
+		if m == nil {
+			continue
+		}
+		check(m.Series, m.Base)
+	}
+	for _, app := range verifier.bd.Applications {
+		if app == nil {
+			continue
+		}
+		check(app.Series, app.Base)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/charm/v9"
+)
+
+type bundleDataSuite struct{}
+
+var _ = gc.Suite(&bundleDataSuite{})
+
+func (s *bundleDataSuite) TestVerifyMixedSeriesBasesMatch(c *gc.C) {
+	data := &charm.BundleData{
+		Series:      "focal",
+		DefaultBase: charm.Base{Name: "ubuntu", Channel: mustParseChannel("18.04/stable")},
+		Applications: map[string]*charm.ApplicationSpec{
+			"wordpress": {
+				Charm:  "wordpress",
+				Series: "focal",
+				Base:   charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+			},
+		},
+	}
+	err := data.Verify(nil, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `bundle series "focal" and base "ubuntu/18.04/stable" must match if supplied`)
+}
+
+func (s *bundleDataSuite) TestVerifyMixedSeriesBasesMatchOK(c *gc.C) {
+	data := &charm.BundleData{
+		Series:      "focal",
+		DefaultBase: charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {Series: "focal", Base: charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/edge")}},
+		},
+	}
+	c.Assert(data.Verify(nil, nil, nil), gc.IsNil)
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Channel identifies and describes completely a store channel.
+// It is a track plus a risk, e.g. "20.04/stable" or "win10/stable".
+type Channel struct {
+	Track string `json:"track" yaml:"track"`
+	Risk  string `json:"risk,omitempty" yaml:"risk,omitempty"`
+}
+
+// validRisks are the set of risk levels a channel may be pinned to.
+var validRisks = map[string]bool{
+	"stable":    true,
+	"candidate": true,
+	"beta":      true,
+	"edge":      true,
+}
+
+// riskRank orders risk levels from least to most stable, so that higher
+// values are preferred when picking between otherwise-equal channels.
+// An unrecognised or empty risk ranks below all of these.
+var riskRank = map[string]int{
+	"edge":      1,
+	"beta":      2,
+	"candidate": 3,
+	"stable":    4,
+}
+
+// ParseChannelNormalize parses a string representing a store channel.
+// The returned channel's track and risk are normalized, e.g. trimmed of
+// surrounding whitespace.
+func ParseChannelNormalize(s string) (Channel, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Channel{}, errors.NotValidf("channel %q", s)
+	}
+
+	var track, risk string
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) == 2 {
+		track, risk = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	} else {
+		risk = parts[0]
+	}
+	if !validRisks[risk] {
+		return Channel{}, errors.NotValidf("risk in channel %q", s)
+	}
+	return Channel{Track: track, Risk: risk}, nil
+}
+
+// String returns the channel in its "track/risk" form, or just the risk
+// if no track is set, or the empty string if the channel itself is empty.
+func (c Channel) String() string {
+	if c.Track == "" && c.Risk == "" {
+		return ""
+	}
+	if c.Track == "" {
+		return c.Risk
+	}
+	return c.Track + "/" + c.Risk
+}
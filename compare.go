@@ -0,0 +1,103 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import "strings"
+
+// Compare returns -1, 0 or 1 depending on whether b sorts before, the
+// same as, or after other. Bases order by OS name (lexically), then by
+// channel (see Channel.Compare), then by architectures compared
+// element-wise.
+func (b Base) Compare(other Base) int {
+	if c := strings.Compare(b.Name, other.Name); c != 0 {
+		return c
+	}
+	if c := b.Channel.Compare(other.Channel); c != 0 {
+		return c
+	}
+	return compareArchitectures(b.Architectures, other.Architectures)
+}
+
+// Compare returns -1, 0 or 1 depending on whether c sorts before, the
+// same as, or after other. Channels order by track, treated as a dotted
+// numeric version where possible (so "22.04" > "20.04" > "18.04", with
+// non-numeric tracks such as "win10" falling back to a lexical compare),
+// then by risk ("edge" < "beta" < "candidate" < "stable").
+func (c Channel) Compare(other Channel) int {
+	if t := compareTracks(c.Track, other.Track); t != 0 {
+		return t
+	}
+	switch cr, or := riskRank[c.Risk], riskRank[other.Risk]; {
+	case cr < or:
+		return -1
+	case cr > or:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareArchitectures orders two architecture slices element-wise, and
+// falls back to comparing lengths once one slice is exhausted.
+func compareArchitectures(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := strings.Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// BasesByPreference implements sort.Interface, ordering bases from least
+// to most preferred according to Base.Compare.
+type BasesByPreference []Base
+
+func (b BasesByPreference) Len() int      { return len(b) }
+func (b BasesByPreference) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b BasesByPreference) Less(i, j int) bool {
+	return b[i].Compare(b[j]) < 0
+}
+
+// ChannelsByPreference implements sort.Interface, ordering channels from
+// least to most preferred according to Channel.Compare.
+type ChannelsByPreference []Channel
+
+func (c ChannelsByPreference) Len() int      { return len(c) }
+func (c ChannelsByPreference) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c ChannelsByPreference) Less(i, j int) bool {
+	return c[i].Compare(c[j]) < 0
+}
+
+// archCompatible reports whether a and b could describe the same
+// deployment target, treating an empty slice on either side as "any
+// architecture".
+func archCompatible(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	return len(archIntersection(a, b)) > 0
+}
+
+// IsCompatibleWith reports whether b and other could describe the same
+// deployment target, treating an empty name, track, risk or architecture
+// list on either side as a wildcard that matches anything.
+func (b Base) IsCompatibleWith(other Base) bool {
+	if b.Name != "" && other.Name != "" && b.Name != other.Name {
+		return false
+	}
+	if b.Channel.Track != "" && other.Channel.Track != "" && b.Channel.Track != other.Channel.Track {
+		return false
+	}
+	if b.Channel.Risk != "" && other.Channel.Risk != "" && b.Channel.Risk != other.Channel.Risk {
+		return false
+	}
+	return archCompatible(b.Architectures, other.Architectures)
+}
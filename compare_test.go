@@ -0,0 +1,90 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	"sort"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/charm/v9"
+)
+
+type compareSuite struct{}
+
+var _ = gc.Suite(&compareSuite{})
+
+func (s *compareSuite) TestChannelCompare(c *gc.C) {
+	tests := []struct {
+		a, b charm.Channel
+		want int
+	}{
+		{mustParseChannel("20.04/stable"), mustParseChannel("22.04/stable"), -1},
+		{mustParseChannel("22.04/stable"), mustParseChannel("20.04/stable"), 1},
+		{mustParseChannel("20.04/stable"), mustParseChannel("20.04/stable"), 0},
+		{mustParseChannel("20.04/edge"), mustParseChannel("20.04/stable"), -1},
+		{mustParseChannel("win10/stable"), mustParseChannel("win7/stable"), -1},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d", i)
+		c.Check(t.a.Compare(t.b), gc.Equals, t.want, comment)
+	}
+}
+
+func (s *compareSuite) TestBaseCompareAndSort(c *gc.C) {
+	bases := []charm.Base{
+		{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")},
+		{Name: "centos", Channel: mustParseChannel("7/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("18.04/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+	}
+	sort.Sort(charm.BasesByPreference(bases))
+	want := []charm.Base{
+		{Name: "centos", Channel: mustParseChannel("7/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("18.04/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+		{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")},
+	}
+	c.Assert(bases, jc.DeepEquals, want)
+}
+
+func (s *compareSuite) TestChannelsByPreference(c *gc.C) {
+	channels := []charm.Channel{
+		mustParseChannel("20.04/stable"),
+		mustParseChannel("20.04/edge"),
+		mustParseChannel("20.04/candidate"),
+	}
+	sort.Sort(charm.ChannelsByPreference(channels))
+	want := []charm.Channel{
+		mustParseChannel("20.04/edge"),
+		mustParseChannel("20.04/candidate"),
+		mustParseChannel("20.04/stable"),
+	}
+	c.Assert(channels, jc.DeepEquals, want)
+}
+
+func (s *compareSuite) TestIsCompatibleWith(c *gc.C) {
+	tests := []struct {
+		a, b       charm.Base
+		compatible bool
+	}{
+		{
+			a:          charm.Base{Name: "ubuntu"},
+			b:          charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+			compatible: true,
+		}, {
+			a:          charm.Base{Name: "ubuntu"},
+			b:          charm.Base{Name: "windows"},
+			compatible: false,
+		}, {
+			a: charm.Base{Name: "ubuntu", Architectures: []string{"amd64"}},
+			b: charm.Base{Name: "ubuntu", Architectures: []string{"arm64"}},
+		},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d", i)
+		c.Check(t.a.IsCompatibleWith(t.b), gc.Equals, t.compatible, comment)
+	}
+}
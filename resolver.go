@@ -0,0 +1,138 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import "fmt"
+
+// SupportedBaseInfo describes the LTS bases that a deployment environment
+// currently recommends.
+type SupportedBaseInfo struct {
+	// DefaultLTS is the LTS base that new deploys should land on unless
+	// the user requests otherwise.
+	DefaultLTS Base
+
+	// LatestLTS is the most recent LTS base known to the source, which
+	// may be newer than DefaultLTS.
+	LatestLTS Base
+
+	// ESMWindow holds bases that are still within extended security
+	// maintenance but are no longer the recommended default.
+	ESMWindow []Base
+}
+
+// SupportedBaseSource supplies the LTS bases a BaseResolver should prefer.
+// It is implemented by environment-specific configuration so that the
+// resolver itself never hard-codes a release name.
+type SupportedBaseSource interface {
+	SupportedBases() SupportedBaseInfo
+}
+
+// BaseResolver picks the base a charm should be deployed on, given the
+// bases the charm declares support for in its manifest.yaml and the
+// (possibly partial) base the user asked for.
+type BaseResolver struct {
+	source SupportedBaseSource
+}
+
+// NewBaseResolver returns a BaseResolver that consults source for the
+// current default and latest LTS bases.
+func NewBaseResolver(source SupportedBaseSource) *BaseResolver {
+	return &BaseResolver{source: source}
+}
+
+// baseIdentity compares the name and channel of two bases, ignoring
+// architectures: two bases are the "same" LTS for resolution purposes
+// regardless of which architectures they were requested for.
+func baseIdentity(a, b Base) bool {
+	return a.Name == b.Name && a.Channel == b.Channel
+}
+
+// matchesRequest reports whether candidate satisfies requested, treating
+// any empty field of requested (name, track or risk) as a wildcard. This
+// lets callers ask for as little as "ubuntu" or "ubuntu/22.04".
+func matchesRequest(requested, candidate Base) bool {
+	if requested.Name != "" && requested.Name != candidate.Name {
+		return false
+	}
+	if requested.Channel.Track != "" && requested.Channel.Track != candidate.Channel.Track {
+		return false
+	}
+	if requested.Channel.Risk != "" && requested.Channel.Risk != candidate.Channel.Risk {
+		return false
+	}
+	return true
+}
+
+// isFullySpecified reports whether requested names an OS, track and
+// risk, i.e. leaves nothing for the LTS-preference steps to arbitrate.
+// A partial request such as "ubuntu" or "ubuntu/22.04" is not exact: it
+// must still go through the default/latest-LTS precedence below rather
+// than matching whichever supported base happens to come first.
+func isFullySpecified(b Base) bool {
+	return b.Name != "" && b.Channel.Track != "" && b.Channel.Risk != ""
+}
+
+// ResolveBase picks the base to deploy a charm on from its supported
+// bases, applying the following precedence:
+//
+//  1. an exact match of the user's requested base (name, track and risk
+//     all given); a partial request such as "ubuntu" or "ubuntu/22.04"
+//     is not exact and falls through to the steps below;
+//  2. the configured default LTS, if the charm supports it;
+//  3. the latest LTS the charm supports;
+//  4. any non-deprecated base the charm supports;
+//  5. otherwise, an error.
+//
+// Preferring the configured default LTS over the latest LTS (when both
+// are supported) prevents new deploys from silently migrating onto an
+// untested release the moment a charm adds support for it — including
+// when the caller only gave a partial request and so never named a
+// specific release in the first place.
+func (r *BaseResolver) ResolveBase(requested Base, supported []Base) (Base, error) {
+	info := r.source.SupportedBases()
+
+	if isFullySpecified(requested) {
+		for _, s := range supported {
+			if matchesRequest(requested, s) {
+				return s, nil
+			}
+		}
+	}
+
+	for _, s := range supported {
+		if baseIdentity(s, info.DefaultLTS) {
+			return s, nil
+		}
+	}
+
+	for _, s := range supported {
+		if baseIdentity(s, info.LatestLTS) {
+			return s, nil
+		}
+	}
+
+	for _, s := range supported {
+		if !r.Deprecated(s) {
+			return s, nil
+		}
+	}
+
+	return Base{}, fmt.Errorf("charm does not support a base matching %q", requested.String())
+}
+
+// Deprecated reports whether base is outside the current default LTS,
+// latest LTS and ESM window, so callers can warn about deploying to an
+// unsupported base without necessarily failing the deploy.
+func (r *BaseResolver) Deprecated(base Base) bool {
+	info := r.source.SupportedBases()
+	if baseIdentity(base, info.DefaultLTS) || baseIdentity(base, info.LatestLTS) {
+		return false
+	}
+	for _, esm := range info.ESMWindow {
+		if baseIdentity(base, esm) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/charm/v9"
+)
+
+type resolverSuite struct{}
+
+var _ = gc.Suite(&resolverSuite{})
+
+type fakeBaseSource charm.SupportedBaseInfo
+
+func (f fakeBaseSource) SupportedBases() charm.SupportedBaseInfo {
+	return charm.SupportedBaseInfo(f)
+}
+
+var jammy = charm.Base{Name: "ubuntu", Channel: mustParseChannel("22.04/stable")}
+var focal = charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")}
+var bionic = charm.Base{Name: "ubuntu", Channel: mustParseChannel("18.04/stable")}
+
+func (s *resolverSuite) source() fakeBaseSource {
+	return fakeBaseSource{
+		DefaultLTS: focal,
+		LatestLTS:  jammy,
+		ESMWindow:  []charm.Base{bionic},
+	}
+}
+
+func (s *resolverSuite) TestResolveBasePrefersDefaultLTSOverLatest(c *gc.C) {
+	r := charm.NewBaseResolver(s.source())
+	base, err := r.ResolveBase(charm.Base{}, []charm.Base{jammy, focal})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(base, jc.DeepEquals, focal)
+}
+
+func (s *resolverSuite) TestResolveBaseExactMatchWins(c *gc.C) {
+	r := charm.NewBaseResolver(s.source())
+	base, err := r.ResolveBase(charm.Base{Name: "ubuntu", Channel: mustParseChannel("18.04/stable")}, []charm.Base{jammy, focal, bionic})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(base, jc.DeepEquals, bionic)
+}
+
+func (s *resolverSuite) TestResolveBasePartialRequestStillPrefersDefaultLTS(c *gc.C) {
+	r := charm.NewBaseResolver(s.source())
+
+	// supported lists the newer release first; a bare OS name must not
+	// match it just because it happens to come first in the manifest.
+	base, err := r.ResolveBase(charm.Base{Name: "ubuntu"}, []charm.Base{jammy, focal})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(base, jc.DeepEquals, focal)
+
+	base, err = r.ResolveBase(charm.Base{Name: "ubuntu", Channel: charm.Channel{Track: "22.04"}}, []charm.Base{jammy, focal})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(base, jc.DeepEquals, focal)
+}
+
+func (s *resolverSuite) TestResolveBaseFallsBackToLatestLTS(c *gc.C) {
+	r := charm.NewBaseResolver(s.source())
+	base, err := r.ResolveBase(charm.Base{}, []charm.Base{jammy})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(base, jc.DeepEquals, jammy)
+}
+
+func (s *resolverSuite) TestResolveBaseNoSupportedBase(c *gc.C) {
+	r := charm.NewBaseResolver(s.source())
+	_, err := r.ResolveBase(charm.Base{Name: "ubuntu", Channel: mustParseChannel("16.04/stable")}, nil)
+	c.Assert(err, gc.ErrorMatches, `charm does not support a base matching "ubuntu/16.04/stable"`)
+}
+
+func (s *resolverSuite) TestDeprecated(c *gc.C) {
+	r := charm.NewBaseResolver(s.source())
+	c.Assert(r.Deprecated(focal), jc.IsFalse)
+	c.Assert(r.Deprecated(jammy), jc.IsFalse)
+	c.Assert(r.Deprecated(bionic), jc.IsFalse)
+	c.Assert(r.Deprecated(charm.Base{Name: "ubuntu", Channel: mustParseChannel("16.04/stable")}), jc.IsTrue)
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import "fmt"
+
+// seriesToBase maps legacy series names to the Base that replaces them.
+// It covers every Ubuntu, Windows and CentOS release that charm tooling
+// has ever had to recognise.
+var seriesToBase = map[string]Base{
+	// Ubuntu.
+	"precise": {Name: "ubuntu", Channel: Channel{Track: "12.04", Risk: "stable"}},
+	"quantal": {Name: "ubuntu", Channel: Channel{Track: "12.10", Risk: "stable"}},
+	"raring":  {Name: "ubuntu", Channel: Channel{Track: "13.04", Risk: "stable"}},
+	"saucy":   {Name: "ubuntu", Channel: Channel{Track: "13.10", Risk: "stable"}},
+	"trusty":  {Name: "ubuntu", Channel: Channel{Track: "14.04", Risk: "stable"}},
+	"utopic":  {Name: "ubuntu", Channel: Channel{Track: "14.10", Risk: "stable"}},
+	"vivid":   {Name: "ubuntu", Channel: Channel{Track: "15.04", Risk: "stable"}},
+	"wily":    {Name: "ubuntu", Channel: Channel{Track: "15.10", Risk: "stable"}},
+	"xenial":  {Name: "ubuntu", Channel: Channel{Track: "16.04", Risk: "stable"}},
+	"yakkety": {Name: "ubuntu", Channel: Channel{Track: "16.10", Risk: "stable"}},
+	"zesty":   {Name: "ubuntu", Channel: Channel{Track: "17.04", Risk: "stable"}},
+	"artful":  {Name: "ubuntu", Channel: Channel{Track: "17.10", Risk: "stable"}},
+	"bionic":  {Name: "ubuntu", Channel: Channel{Track: "18.04", Risk: "stable"}},
+	"cosmic":  {Name: "ubuntu", Channel: Channel{Track: "18.10", Risk: "stable"}},
+	"disco":   {Name: "ubuntu", Channel: Channel{Track: "19.04", Risk: "stable"}},
+	"eoan":    {Name: "ubuntu", Channel: Channel{Track: "19.10", Risk: "stable"}},
+	"focal":   {Name: "ubuntu", Channel: Channel{Track: "20.04", Risk: "stable"}},
+	"groovy":  {Name: "ubuntu", Channel: Channel{Track: "20.10", Risk: "stable"}},
+	"hirsute": {Name: "ubuntu", Channel: Channel{Track: "21.04", Risk: "stable"}},
+	"impish":  {Name: "ubuntu", Channel: Channel{Track: "21.10", Risk: "stable"}},
+	"jammy":   {Name: "ubuntu", Channel: Channel{Track: "22.04", Risk: "stable"}},
+
+	// Windows.
+	"win7":        {Name: "windows", Channel: Channel{Track: "win7", Risk: "stable"}},
+	"win8":        {Name: "windows", Channel: Channel{Track: "win8", Risk: "stable"}},
+	"win81":       {Name: "windows", Channel: Channel{Track: "win81", Risk: "stable"}},
+	"win10":       {Name: "windows", Channel: Channel{Track: "win10", Risk: "stable"}},
+	"win2008r2":   {Name: "windows", Channel: Channel{Track: "win2008r2", Risk: "stable"}},
+	"win2012":     {Name: "windows", Channel: Channel{Track: "win2012", Risk: "stable"}},
+	"win2012r2":   {Name: "windows", Channel: Channel{Track: "win2012r2", Risk: "stable"}},
+	"win2012hvr2": {Name: "windows", Channel: Channel{Track: "win2012hvr2", Risk: "stable"}},
+	"win2012hv":   {Name: "windows", Channel: Channel{Track: "win2012hv", Risk: "stable"}},
+	"win2016":     {Name: "windows", Channel: Channel{Track: "win2016", Risk: "stable"}},
+	"win2016hv":   {Name: "windows", Channel: Channel{Track: "win2016hv", Risk: "stable"}},
+	"win2016nano": {Name: "windows", Channel: Channel{Track: "win2016nano", Risk: "stable"}},
+	"win2019":     {Name: "windows", Channel: Channel{Track: "win2019", Risk: "stable"}},
+	"win2019nano": {Name: "windows", Channel: Channel{Track: "win2019nano", Risk: "stable"}},
+
+	// CentOS.
+	"centos7": {Name: "centos", Channel: Channel{Track: "7", Risk: "stable"}},
+	"centos8": {Name: "centos", Channel: Channel{Track: "8", Risk: "stable"}},
+	"centos9": {Name: "centos", Channel: Channel{Track: "9", Risk: "stable"}},
+}
+
+// baseToSeries is the inverse of seriesToBase, keyed on the base's
+// "os/track" string since risk is not part of a legacy series name.
+var baseToSeries = func() map[string]string {
+	m := make(map[string]string, len(seriesToBase))
+	for series, base := range seriesToBase {
+		m[base.Name+"/"+base.Channel.Track] = series
+	}
+	return m
+}()
+
+// BaseFromSeries returns the Base corresponding to the given legacy
+// series name, such as "focal" or "win10".
+func BaseFromSeries(series string) (Base, error) {
+	base, ok := seriesToBase[series]
+	if !ok {
+		return Base{}, fmt.Errorf("series %q not valid", series)
+	}
+	return base, nil
+}
+
+// SeriesFromBase returns the legacy series name corresponding to base,
+// ignoring the base's risk and architectures (a series has neither).
+func SeriesFromBase(base Base) (string, error) {
+	series, ok := baseToSeries[base.Name+"/"+base.Channel.Track]
+	if !ok {
+		return "", fmt.Errorf("no series corresponding to base %q", base.String())
+	}
+	return series, nil
+}
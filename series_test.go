@@ -0,0 +1,76 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/charm/v9"
+)
+
+type seriesSuite struct{}
+
+var _ = gc.Suite(&seriesSuite{})
+
+func (s *seriesSuite) TestBaseFromSeries(c *gc.C) {
+	tests := []struct {
+		series string
+		base   charm.Base
+		err    string
+	}{
+		{
+			series: "focal",
+			base:   charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+		}, {
+			series: "win10",
+			base:   charm.Base{Name: "windows", Channel: mustParseChannel("win10/stable")},
+		}, {
+			series: "centos7",
+			base:   charm.Base{Name: "centos", Channel: mustParseChannel("7/stable")},
+		}, {
+			series: "nonexistent",
+			err:    `series "nonexistent" not valid`,
+		},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d", i)
+		base, err := charm.BaseFromSeries(t.series)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err, comment)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil, comment)
+		c.Check(base, jc.DeepEquals, t.base, comment)
+	}
+}
+
+func (s *seriesSuite) TestSeriesFromBase(c *gc.C) {
+	tests := []struct {
+		base   charm.Base
+		series string
+		err    string
+	}{
+		{
+			base:   charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/stable")},
+			series: "focal",
+		}, {
+			base:   charm.Base{Name: "ubuntu", Channel: mustParseChannel("20.04/edge")},
+			series: "focal",
+		}, {
+			base: charm.Base{Name: "ubuntu", Channel: mustParseChannel("99.04/stable")},
+			err:  `no series corresponding to base "ubuntu/99.04/stable"`,
+		},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d", i)
+		series, err := charm.SeriesFromBase(t.base)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err, comment)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil, comment)
+		c.Check(series, gc.Equals, t.series, comment)
+	}
+}
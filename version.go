@@ -0,0 +1,53 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package charm
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareTracks orders two channel tracks as dotted numeric versions, so
+// that "22.04" > "20.04" > "18.04". If either track is not purely dotted
+// numeric (e.g. "win10"), it falls back to a lexical compare between the
+// two original strings.
+func compareTracks(a, b string) int {
+	av, aok := parseDottedVersion(a)
+	bv, bok := parseDottedVersion(b)
+	if !aok || !bok {
+		return strings.Compare(a, b)
+	}
+	for i := 0; i < len(av) || i < len(bv); i++ {
+		var x, y int
+		if i < len(av) {
+			x = av[i]
+		}
+		if i < len(bv) {
+			y = bv[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseDottedVersion splits a track like "22.04" into its numeric
+// components. It returns ok=false if any component isn't a number.
+func parseDottedVersion(s string) (parts []int, ok bool) {
+	if s == "" {
+		return nil, false
+	}
+	for _, p := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}